@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/stevemcquaid/mcq/pkg/commands"
@@ -11,7 +13,9 @@ var CICmd = &cobra.Command{
 	Short: "Run almost everything",
 	Long:  `This subcommand runs all the tests and code checks`,
 	Run: func(cmd *cobra.Command, args []string) {
-		_ = commands.CI()
+		if err := commands.CIWithFailFast(FailFastFlag); err != nil {
+			os.Exit(1)
+		}
 	},
 }
 
@@ -20,11 +24,14 @@ var AllCmd = &cobra.Command{
 	Short: "Run everything",
 	Long:  `This subcommand runs everything`,
 	Run: func(cmd *cobra.Command, args []string) {
-		_ = commands.All()
+		_ = commands.All(dockerImage())
 	},
 }
 
+var FailFastFlag bool
+
 func init() {
+	CICmd.Flags().BoolVar(&FailFastFlag, "fail-fast", true, "Stop at the first failing stage, set to false to run every stage and summarize failures")
 	RootCmd.AddCommand(CICmd)
 	RootCmd.AddCommand(AllCmd)
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/stevemcquaid/mcq/pkg/commands"
@@ -9,12 +11,18 @@ import (
 var coverCmd = &cobra.Command{
 	Use:   "cover",
 	Short: "-> go tool cover",
-	Long:  `This subcommand runs all the tests and opens the coverage report`,
+	Long: `This subcommand runs all the tests and opens the coverage report.
+It exits non-zero if any step fails, or if total coverage is below --min`,
 	Run: func(cmd *cobra.Command, args []string) {
-		_ = commands.Cover()
+		if err := commands.Cover(MinCoverageFlag); err != nil {
+			os.Exit(1)
+		}
 	},
 }
 
+var MinCoverageFlag float64
+
 func init() {
+	coverCmd.Flags().Float64Var(&MinCoverageFlag, "min", 0, "Fail if total coverage is below this percentage")
 	RootCmd.AddCommand(coverCmd)
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/stevemcquaid/mcq/pkg/commands"
@@ -11,10 +13,19 @@ var depsCmd = &cobra.Command{
 	Short: "-> go mod tidy, download, vendor",
 	Long:  `This subcommand runs go mod tidy, download & vendor `,
 	Run: func(cmd *cobra.Command, args []string) {
+		if DepsCheckFlag {
+			if err := commands.DepsCheck(); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
 		_ = commands.Deps()
 	},
 }
 
+var DepsCheckFlag bool
+
 func init() {
+	depsCmd.Flags().BoolVarP(&DepsCheckFlag, "check", "c", false, "Fail if go.mod/go.sum are not tidy or fail verification, without modifying them")
 	RootCmd.AddCommand(depsCmd)
 }
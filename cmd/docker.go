@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"path"
 
 	"github.com/spf13/cobra"
@@ -23,11 +22,10 @@ var dockerBuildCmd = &cobra.Command{
 	Short: "docker build",
 	Long:  `This subcommand builds the dockerfile`,
 	Run: func(cmd *cobra.Command, args []string) {
-		gitOrg := viper.GetString("GIT_ORG")
-		gitRepo := viper.GetString("GIT_REPO")
-		dockerBase := path.Join(gitOrg, gitRepo)
-		dockerImage := fmt.Sprintf("%s:%s", dockerBase, "latest")
-		_ = commands.DockerBuild(dockerImage)
+		_ = commands.DockerBuildWithOptions(dockerImage(), commands.DockerBuildOptions{
+			Target:    TargetFlag,
+			BuildArgs: BuildArgFlag,
+		})
 	},
 }
 
@@ -37,11 +35,7 @@ var dockerRunCmd = &cobra.Command{
 	Short: "docker run",
 	Long:  `This subcommand runs docker`,
 	Run: func(cmd *cobra.Command, args []string) {
-		gitOrg := viper.GetString("GIT_ORG")
-		gitRepo := viper.GetString("GIT_REPO")
-		dockerBase := path.Join(gitOrg, gitRepo)
-		dockerImage := fmt.Sprintf("%s:%s", dockerBase, "latest")
-		_ = commands.DockerRun(dockerImage)
+		_ = commands.DockerRun(dockerImage())
 	},
 }
 
@@ -51,15 +45,31 @@ var dockerPushCmd = &cobra.Command{
 	Short: "docker push",
 	Long:  `This subcommand runs docker push`,
 	Run: func(cmd *cobra.Command, args []string) {
-		gitOrg := viper.GetString("GIT_ORG")
-		gitRepo := viper.GetString("GIT_REPO")
-		dockerBase := path.Join(gitOrg, gitRepo)
-		dockerImage := fmt.Sprintf("%s:%s", dockerBase, "latest")
-		_ = commands.DockerPush(dockerImage)
+		_ = commands.DockerPush(dockerImage())
 	},
 }
 
+// dockerImage returns the image reference from --image and --tag, the name defaults to GIT_ORG/GIT_REPO
+func dockerImage() string {
+	image := ImageFlag
+	if image == "" {
+		image = path.Join(viper.GetString("GIT_ORG"), viper.GetString("GIT_REPO"))
+	}
+	return commands.GetDockerImage(image, TagFlag)
+}
+
+var (
+	ImageFlag    string
+	TagFlag      string
+	TargetFlag   string
+	BuildArgFlag []string
+)
+
 func init() {
+	dockerBuildCmd.Flags().StringVar(&TargetFlag, "target", "final", "Dockerfile stage to build")
+	dockerBuildCmd.Flags().StringArrayVar(&BuildArgFlag, "build-arg", nil, "Build argument as KEY=VAL (repeatable)")
+	dockerCmd.PersistentFlags().StringVar(&ImageFlag, "image", "", "Image name (default: <git org>/<git repo>)")
+	dockerCmd.PersistentFlags().StringVarP(&TagFlag, "tag", "t", "", "Image tag (default: $MCQ_DOCKER_TAG, then the git short SHA, then latest)")
 	RootCmd.AddCommand(dockerCmd)
 	dockerCmd.AddCommand(dockerRunCmd)
 	dockerCmd.AddCommand(dockerBuildCmd)
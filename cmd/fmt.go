@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/stevemcquaid/mcq/pkg/commands"
@@ -11,10 +13,19 @@ var fmtCmd = &cobra.Command{
 	Short: "-> go fmt",
 	Long:  `This subcommand runs go fmt on all code`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if FmtCheckFlag {
+			if err := commands.FmtCheck(); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
 		_ = commands.Fmt()
 	},
 }
 
+var FmtCheckFlag bool
+
 func init() {
+	fmtCmd.Flags().BoolVarP(&FmtCheckFlag, "check", "c", false, "List unformatted files and fail instead of rewriting them")
 	RootCmd.AddCommand(fmtCmd)
 }
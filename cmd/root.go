@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/stevemcquaid/mcq/pkg/commands"
+	"github.com/stevemcquaid/mcq/pkg/shell"
 )
 
 // RootCmd represents the base command when called without any subcommands
@@ -32,18 +34,54 @@ func Execute() {
 	}
 }
 
+var (
+	NoColorFlag  bool
+	ConfigFlag   string
+	DryRunFlag   bool
+	NoDotEnvFlag bool
+)
+
 func init() {
 	cobra.OnInitialize(initConfig)
+	RootCmd.PersistentFlags().BoolVar(&NoColorFlag, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+	RootCmd.PersistentFlags().BoolVar(&DryRunFlag, "dry-run", false, "Print the shell commands that would run without executing them")
+	RootCmd.PersistentFlags().BoolVar(&NoDotEnvFlag, "no-dotenv", false, "Do not load .env from the working directory or ~/.config/mcq")
+	RootCmd.PersistentFlags().StringVar(&ConfigFlag, "config", "", "Config file to read (any format supported by viper: yaml, json, toml...)")
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
-	// Load the PWD golang module name
+	if !NoDotEnvFlag {
+		if err := commands.LoadDotEnv(commands.DotEnvPaths()...); err != nil {
+			fmt.Printf("unable to load .env: %s\n", err)
+		}
+	}
+
+	// color.NoColor is computed before .env is loaded, so NO_COLOR from .env is checked again here
+	if _, noColorEnv := os.LookupEnv("NO_COLOR"); NoColorFlag || noColorEnv {
+		color.NoColor = true
+	}
+	shell.DryRun = DryRunFlag
+
+	if ConfigFlag != "" {
+		if _, err := os.Stat(ConfigFlag); err != nil {
+			fmt.Printf("unable to find config file %s: %s\n", ConfigFlag, err)
+			os.Exit(1)
+		}
+
+		viper.SetConfigFile(ConfigFlag)
+		if err := viper.ReadInConfig(); err != nil {
+			fmt.Printf("unable to read config file %s: %s\n", ConfigFlag, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the PWD golang module name, values from the config file take precedence
 	gitOrg, gitRepo, err := commands.GetModules()
 	if err != nil {
 		fmt.Println("unable to set GIT_ORG + GIT_REPO")
 	}
 
-	viper.Set("GIT_ORG", gitOrg)
-	viper.Set("GIT_REPO", gitRepo)
+	viper.SetDefault("GIT_ORG", gitOrg)
+	viper.SetDefault("GIT_REPO", gitRepo)
 }
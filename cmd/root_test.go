@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestInitConfigNoColorFlag(t *testing.T) {
+	defer func(noColor, noColorFlag, noDotEnvFlag bool) {
+		color.NoColor = noColor
+		NoColorFlag = noColorFlag
+		NoDotEnvFlag = noDotEnvFlag
+	}(color.NoColor, NoColorFlag, NoDotEnvFlag)
+
+	color.NoColor = false
+	NoColorFlag = true
+	NoDotEnvFlag = true
+	initConfig()
+
+	if !color.NoColor {
+		t.Error("initConfig() with --no-color did not disable color")
+	}
+}
+
+func TestInitConfigNoColorFromDotEnv(t *testing.T) {
+	defer func(noColor, noColorFlag, noDotEnvFlag bool) {
+		color.NoColor = noColor
+		NoColorFlag = noColorFlag
+		NoDotEnvFlag = noDotEnvFlag
+	}(color.NoColor, NoColorFlag, NoDotEnvFlag)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("NO_COLOR=1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+	t.Setenv("NO_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+
+	color.NoColor = false
+	NoColorFlag = false
+	NoDotEnvFlag = false
+	initConfig()
+
+	if !color.NoColor {
+		t.Error("initConfig() did not apply NO_COLOR from .env")
+	}
+}
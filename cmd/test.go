@@ -11,7 +11,7 @@ var testUnitCmd = &cobra.Command{
 	Short: "-> go test -tags=unit",
 	Long:  `This subcommand runs unit tests`,
 	Run: func(cmd *cobra.Command, args []string) {
-		_ = commands.TestUnit()
+		_ = commands.TestUnitWithOptions(testOptions())
 	},
 }
 
@@ -29,11 +29,31 @@ var testCmd = &cobra.Command{
 	Short: "-> go test",
 	Long:  `This subcommand runs all tests`,
 	Run: func(cmd *cobra.Command, args []string) {
-		_ = commands.Test()
+		_ = commands.TestWithOptions(testOptions())
 	},
 }
 
+var (
+	RaceFlag bool
+	RunFlag  string
+	PkgFlag  string
+)
+
+func testOptions() commands.TestOptions {
+	return commands.TestOptions{
+		Race: RaceFlag,
+		Run:  RunFlag,
+		Pkg:  PkgFlag,
+	}
+}
+
 func init() {
+	// integration tests do not take TestOptions, so only the commands that honor them get the flags
+	for _, c := range []*cobra.Command{testCmd, testUnitCmd} {
+		c.Flags().BoolVar(&RaceFlag, "race", false, "Enable the race detector")
+		c.Flags().StringVar(&RunFlag, "run", "", "Only run tests matching this pattern (default \".\")")
+		c.Flags().StringVar(&PkgFlag, "pkg", "", "Only test this package instead of ./...")
+	}
 	RootCmd.AddCommand(testCmd)
 	testCmd.AddCommand(testUnitCmd)
 	testCmd.AddCommand(testIntegratinoCmd)
@@ -6,7 +6,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var version = "0.1.9"
+// version, commit and buildDate are set at build time by `make install`, ie:
+// go build -ldflags "-X github.com/stevemcquaid/mcq/cmd.version=$(git describe --tags --always)"
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildDate = "unknown"
+)
 
 var versionCmd = &cobra.Command{
 	Use:     "version",
@@ -14,10 +20,17 @@ var versionCmd = &cobra.Command{
 	Aliases: []string{"v", "-v"},
 	Long:    `This subcommand returns the version of the CLI utility`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println(version)
+		if ShortFlag {
+			fmt.Println(version)
+			return
+		}
+		fmt.Printf("%s (commit: %s, built: %s)\n", version, commit, buildDate)
 	},
 }
 
+var ShortFlag bool
+
 func init() {
+	versionCmd.Flags().BoolVarP(&ShortFlag, "short", "s", false, "Print only the version number")
 	RootCmd.AddCommand(versionCmd)
 }
@@ -4,10 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"reflect"
+	"os"
 	"unsafe"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 )
 
 // PrefixWriter is an implementation of io.Writer which places a prefix before
@@ -21,16 +22,23 @@ type ColorWriter struct {
 	buffer []byte
 	offset int
 	color  *color.Color
+	// noColor drops the escape sequences while keeping the line buffering
+	noColor bool
 }
 
 // NewPrefixWriter constructs a PrefixWriter which outputs to w and prefixes
 // every line with s.
+//
+// fatih/color already drops color when color.NoColor is set, which it does for
+// NO_COLOR or a non-terminal stdout, and which mcq sets for --no-color. The
+// writer also drops color when w itself is not a terminal, eg. a file or buffer.
 func NewPrefixWriter(w io.Writer, c *color.Color) *ColorWriter {
 	return &ColorWriter{
-		color:  c,
-		writer: w,
-		indent: copyStringToBytes(""),
-		buffer: make([]byte, 0, 256),
+		color:   c,
+		noColor: color.NoColor || !IsTerminal(w),
+		writer:  w,
+		indent:  copyStringToBytes(""),
+		buffer:  make([]byte, 0, 256),
 	}
 }
 
@@ -111,15 +119,28 @@ func (w *ColorWriter) writeLine(b []byte) (int, error) {
 	return w.write(b)
 }
 
+// write passes b on to the underlying writer immediately. Write only calls it with complete lines,
+// so each line reaches the terminal as soon as its newline arrives. In color mode the color
+// sequences and the text are separate writes
 func (w *ColorWriter) write(b []byte) (int, error) {
+	if w.noColor {
+		return w.writer.Write(b)
+	}
 	return w.color.Fprint(w.writer, BytesToString(b))
-	// return w.writer.Write(b)
 }
 
+// BytesToString converts b to a string without copying. The string must not outlive b's next modification
 func BytesToString(b []byte) string {
-	bh := (*reflect.SliceHeader)(unsafe.Pointer(&b))
-	sh := reflect.StringHeader{Data: bh.Data, Len: bh.Len}
-	return *(*string)(unsafe.Pointer(&sh))
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// IsTerminal reports whether w is a file attached to a terminal, including Cygwin terminals
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
 }
 
 func (w *ColorWriter) discard(n int) {
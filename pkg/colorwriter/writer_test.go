@@ -0,0 +1,99 @@
+package colorwriter
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/segmentio/textio"
+)
+
+func TestColorWriterNonTerminal(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{
+			name:   "complete line is written without escape sequences",
+			writes: []string{"hello\n"},
+			want:   "hello\n",
+		}, {
+			name:   "partial line is held until the newline arrives",
+			writes: []string{"||    ", "hello"},
+			want:   "",
+		}, {
+			name:   "partial writes are joined into one line",
+			writes: []string{"||    ", "hello\n"},
+			want:   "||    hello\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewPrefixWriter(&buf, color.New(color.FgRed))
+			for _, s := range tt.writes {
+				if _, err := w.WriteString(s); err != nil {
+					t.Fatalf("WriteString() error = %v", err)
+				}
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// recordingWriter keeps each call to Write separately
+type recordingWriter struct {
+	writes []string
+}
+
+func (r *recordingWriter) Write(b []byte) (int, error) {
+	r.writes = append(r.writes, string(b))
+	return len(b), nil
+}
+
+func TestColorWriterDeliversEachLine(t *testing.T) {
+	var out recordingWriter
+	w := textio.NewPrefixWriter(NewPrefixWriter(&out, color.New(color.FgCyan)), "||    ")
+
+	steps := []struct {
+		write string
+		want  []string
+	}{
+		{write: "first\nsec", want: []string{"||    first\n"}},
+		{write: "ond\n", want: []string{"||    first\n", "||    second\n"}},
+		{write: "third", want: []string{"||    first\n", "||    second\n"}},
+	}
+	for _, step := range steps {
+		if _, err := w.Write([]byte(step.write)); err != nil {
+			t.Fatalf("Write(%q) error = %v", step.write, err)
+		}
+		if got := out.writes; !reflect.DeepEqual(got, step.want) {
+			t.Fatalf("after Write(%q) writes = %q, want %q", step.write, got, step.want)
+		}
+	}
+}
+
+func TestNewPrefixWriterHonorsNoColor(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+
+	color.NoColor = true
+	if w := NewPrefixWriter(os.Stdout, color.New(color.FgRed)); !w.noColor {
+		t.Error("NewPrefixWriter() kept color with color.NoColor set")
+	}
+
+	color.NoColor = false
+	if w := NewPrefixWriter(&bytes.Buffer{}, color.New(color.FgRed)); !w.noColor {
+		t.Error("NewPrefixWriter() kept color for a non-terminal writer")
+	}
+}
+
+func TestBytesToString(t *testing.T) {
+	if got := BytesToString([]byte("||    hello\n")); got != "||    hello\n" {
+		t.Errorf("BytesToString() = %q", got)
+	}
+}
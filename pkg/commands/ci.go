@@ -2,18 +2,13 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/stevemcquaid/mcq/pkg/shell"
 )
 
-// Run all the tests and code checks
-func All() error {
-	dockerImage, err := GetDockerImage()
-	if err != nil {
-		fmt.Printf("\n \n \n GOT ERROR: %s", err)
-		return err
-	}
-
+// Run all the tests and code checks, then build dockerImage
+func All(dockerImage string) error {
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
 			&shell.VoidFunction{
@@ -32,28 +27,53 @@ func All() error {
 
 // Run all the tests and code checks
 func CI() error {
-	return shell.OrderedRunner(
-		[]shell.RunningFunction{
-			&shell.VoidFunction{
-				Function: Deps,
-			},
-			&shell.VoidFunction{
-				Function: Fmt,
-			},
-			&shell.VoidFunction{
-				Function: Vet,
-			},
-			&shell.BoolFunction{
-				Function: Lint,
-				Arg:      false,
-			},
-			&shell.VoidFunction{
-				Function: Test,
-			},
-			&shell.StringSliceFunction{
-				Function: Install,
-				Arg:      []string{},
+	return CIWithFailFast(true)
+}
+
+// ciStage is a named step of the CI pipeline
+type ciStage struct {
+	name     string
+	function shell.RunningFunction
+}
+
+// CIWithFailFast runs all the tests and code checks. When failFast is false every stage runs,
+// and the stages that failed are summarized at the end
+func CIWithFailFast(failFast bool) error {
+	stages := []ciStage{
+		{name: "deps", function: &shell.VoidFunction{Function: Deps}},
+		{name: "fmt", function: &shell.VoidFunction{Function: Fmt}},
+		{name: "vet", function: &shell.VoidFunction{Function: Vet}},
+		{name: "lint", function: &shell.BoolFunction{Function: Lint, Arg: false}},
+		{name: "test", function: &shell.VoidFunction{Function: Test}},
+		{name: "install", function: &shell.StringSliceFunction{Function: Install, Arg: []string{}}},
+	}
+
+	if failFast {
+		queue := make([]shell.RunningFunction, len(stages))
+		for i, stage := range stages {
+			queue[i] = stage.function
+		}
+		return shell.OrderedRunner(queue)
+	}
+
+	var failed []string
+	queue := make([]shell.RunningFunction, len(stages))
+	for i, stage := range stages {
+		stage := stage
+		queue[i] = &shell.VoidFunction{
+			Function: func() error {
+				err := stage.function.Run()
+				if err != nil {
+					failed = append(failed, stage.name)
+				}
+				return err
 			},
-		},
-	)
+		}
+	}
+
+	err := shell.OrderedRunnerIgnoreErrors(queue)
+	if err != nil {
+		fmt.Printf("\n===> ci failed stages: %s\n", strings.Join(failed, ", "))
+	}
+	return err
 }
@@ -10,9 +10,6 @@ func Clean() error {
 			&shell.VoidFunction{
 				Function: Fmt,
 			},
-			&shell.VoidFunction{
-				Function: Fumpt,
-			},
 			&shell.StringFunction{
 				Arg:      "go mod tidy",
 				Function: shell.PrettyRun,
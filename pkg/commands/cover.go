@@ -1,24 +1,102 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/stevemcquaid/mcq/pkg/colorwriter"
 	"github.com/stevemcquaid/mcq/pkg/shell"
 )
 
-// Run all the tests and opens the coverage report
-func Cover() error {
-	return shell.OrderedRunner(
-		[]shell.RunningFunction{
-			&shell.VoidFunction{
-				Function: Test,
-			},
-			&shell.StringFunction{
-				Arg:      "gocovmerge build/unit.out > build/all.out",
-				Function: shell.PrettyRun,
-			},
-			&shell.StringFunction{
-				Arg:      "go tool cover -html=build/all.out",
-				Function: shell.PrettyRun,
-			},
+const coverProfile = "build/all.out"
+
+// Run all the tests and opens the coverage report.
+// If threshold is above zero, also fails when the total coverage is below it.
+// The report is only opened when stdout is a terminal
+func Cover(threshold float64) error {
+	queue := []shell.RunningFunction{
+		&shell.VoidFunction{
+			Function: Test,
 		},
-	)
+		&shell.StringFunction{
+			Arg:      "gocovmerge build/unit.out > " + coverProfile,
+			Function: shell.PrettyRun,
+		},
+	}
+
+	if threshold > 0 {
+		queue = append(queue, &shell.VoidFunction{
+			Function: func() error {
+				return CheckCoverage(coverProfile, threshold)
+			},
+		})
+	}
+
+	if colorwriter.IsTerminal(os.Stdout) {
+		queue = append(queue, &shell.StringFunction{
+			Arg:      "go tool cover -html=" + coverProfile,
+			Function: shell.PrettyRun,
+		})
+	}
+
+	return shell.OrderedRunner(queue)
+}
+
+// CheckCoverage fails if the total coverage in profile is below threshold percent.
+// The reason is printed, since callers only see PrettyRun output for the other steps
+func CheckCoverage(profile string, threshold float64) error {
+	if shell.DryRun {
+		fmt.Printf("===> check total coverage in %s is at least %.1f%%\n", profile, threshold)
+		return nil
+	}
+
+	err := checkCoverage(profile, threshold)
+	if err != nil {
+		fmt.Println(err)
+	}
+	return err
+}
+
+func checkCoverage(profile string, threshold float64) error {
+	output, err := exec.Command("go", "tool", "cover", "-func="+profile).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return fmt.Errorf("unable to read coverage from %s: %w: %s", profile, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return fmt.Errorf("unable to read coverage from %s: %w", profile, err)
+	}
+
+	total, err := parseCoverageTotal(string(output))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("total coverage: %.1f%% (minimum %.1f%%)\n", total, threshold)
+	if total < threshold {
+		return fmt.Errorf("coverage %.1f%% is below the minimum of %.1f%%", total, threshold)
+	}
+	return nil
+}
+
+// parseCoverageTotal reads the percentage from the "total:" line of `go tool cover -func` output
+func parseCoverageTotal(output string) (float64, error) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "total:" {
+			continue
+		}
+
+		percent := strings.TrimSuffix(fields[len(fields)-1], "%")
+		total, err := strconv.ParseFloat(percent, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse total coverage %q: %w", line, err)
+		}
+		return total, nil
+	}
+	return 0, fmt.Errorf("total coverage not found")
 }
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_parseCoverageTotal(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name: "total line",
+			output: "github.com/stevemcquaid/mcq/pkg/shell/shell.go:20:\tPrettyRun\t\t100.0%\n" +
+				"total:\t\t\t\t\t\t(statements)\t72.4%\n",
+			want: 72.4,
+		}, {
+			name:    "missing total",
+			output:  "github.com/stevemcquaid/mcq/pkg/shell/shell.go:20:\tPrettyRun\t\t100.0%\n",
+			wantErr: true,
+		}, {
+			name:    "malformed total",
+			output:  "total:\t(statements)\tabc%\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCoverageTotal(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCoverageTotal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseCoverageTotal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCoverageMissingProfile(t *testing.T) {
+	err := CheckCoverage(filepath.Join(t.TempDir(), "missing.out"), 50)
+	if err == nil || !strings.Contains(err.Error(), "missing.out") || !strings.Contains(err.Error(), "no such file") {
+		t.Errorf("CheckCoverage() error = %v, want it to include the go tool cover stderr", err)
+	}
+}
@@ -1,6 +1,13 @@
 package commands
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
 	"github.com/stevemcquaid/mcq/pkg/shell"
 )
 
@@ -22,3 +29,87 @@ func Deps() error {
 		},
 	)
 }
+
+// DepsCheck fails if go mod tidy would change go.mod or go.sum, or if go mod verify fails.
+// It leaves the tree unmodified
+func DepsCheck() error {
+	return shell.OrderedRunner(
+		[]shell.RunningFunction{
+			&shell.VoidFunction{
+				Function: checkTidy,
+			},
+			&shell.StringFunction{
+				Arg:      "go mod verify",
+				Function: shell.PrettyRun,
+			},
+		},
+	)
+}
+
+var modFiles = []string{"go.mod", "go.sum"}
+
+// checkTidy runs go mod tidy and reports which module files it changed, restoring their original content
+func checkTidy() (err error) {
+	if shell.DryRun {
+		fmt.Println("===> check go mod tidy leaves go.mod and go.sum unchanged")
+		return nil
+	}
+
+	original := map[string][]byte{}
+	for _, file := range modFiles {
+		content, readErr := os.ReadFile(file)
+		if errors.Is(readErr, fs.ErrNotExist) {
+			continue
+		}
+		if readErr != nil {
+			return readErr
+		}
+		original[file] = content
+	}
+
+	defer func() {
+		if restoreErr := restoreModFiles(original); restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+	}()
+
+	if err := shell.PrettyRun("go mod tidy"); err != nil {
+		return err
+	}
+
+	var changed []string
+	for _, file := range modFiles {
+		content, readErr := os.ReadFile(file)
+		if readErr != nil && !errors.Is(readErr, fs.ErrNotExist) {
+			return readErr
+		}
+		before, existed := original[file]
+		if existed != (readErr == nil) || !bytes.Equal(before, content) {
+			changed = append(changed, file)
+		}
+	}
+
+	if len(changed) > 0 {
+		err := fmt.Errorf("go mod tidy would change %s, run `mcq deps`", strings.Join(changed, ", "))
+		fmt.Println(err)
+		return err
+	}
+	return nil
+}
+
+// restoreModFiles writes back the original module files, removing any that did not exist before
+func restoreModFiles(original map[string][]byte) error {
+	for _, file := range modFiles {
+		content, existed := original[file]
+		if !existed {
+			if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+		if err := os.WriteFile(file, content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
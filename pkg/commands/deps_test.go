@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stevemcquaid/mcq/pkg/shell"
+)
+
+func Test_checkTidy(t *testing.T) {
+	tests := []struct {
+		name    string
+		goMod   string
+		wantErr bool
+	}{
+		{
+			name:  "tidy module",
+			goMod: "module example.com/tidy\n\ngo 1.19\n",
+		}, {
+			name:    "untidy module",
+			goMod:   "module    example.com/untidy\n\n\n\ngo 1.19\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, "go.mod"), tt.goMod)
+			writeFile(t, filepath.Join(dir, "x.go"), "package x\n")
+			chdir(t, dir)
+			t.Setenv("GOFLAGS", "-mod=mod")
+
+			err := checkTidy()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkTidy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got, err := os.ReadFile("go.mod")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.goMod {
+				t.Errorf("go.mod = %q, want it restored to %q", got, tt.goMod)
+			}
+		})
+	}
+}
+
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func Test_checkTidyDryRun(t *testing.T) {
+	defer func(dryRun bool) { shell.DryRun = dryRun }(shell.DryRun)
+	shell.DryRun = true
+
+	dir := t.TempDir()
+	goMod := filepath.Join(dir, "go.mod")
+	writeFile(t, goMod, "module example.com/x\n\ngo 1.19\n")
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(goMod, old, old); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+
+	if err := checkTidy(); err != nil {
+		t.Fatalf("checkTidy() error = %v", err)
+	}
+
+	info, err := os.Stat(goMod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Error("checkTidy() rewrote go.mod in dry run")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.sum")); !os.IsNotExist(err) {
+		t.Errorf("checkTidy() touched go.sum in dry run: %v", err)
+	}
+}
@@ -2,27 +2,65 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/stevemcquaid/mcq/pkg/shell"
 )
 
+// DockerBuildOptions customizes the docker build invocation
+type DockerBuildOptions struct {
+	// Target is the build stage, defaults to "final"
+	Target string
+	// BuildArgs are passed as --build-arg, each formatted KEY=VAL
+	BuildArgs []string
+}
+
 func DockerBuild(dockerImage string) error {
+	return DockerBuildWithOptions(dockerImage, DockerBuildOptions{})
+}
+
+func DockerBuildWithOptions(dockerImage string, opts DockerBuildOptions) error {
+	command, err := getDockerBuildCommand(dockerImage, opts)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
 			&shell.StringFunction{
-				Arg:      fmt.Sprintf("docker build --target final -t %s .", dockerImage),
+				Arg:      command,
 				Function: shell.PrettyRun,
 			},
 		},
 	)
 }
 
+func getDockerBuildCommand(dockerImage string, opts DockerBuildOptions) (string, error) {
+	target := opts.Target
+	if target == "" {
+		target = "final"
+	}
+
+	command := []string{"docker build", fmt.Sprintf("--target %s", shellQuote(target))}
+	for _, buildArg := range opts.BuildArgs {
+		key, _, found := strings.Cut(buildArg, "=")
+		if !found || key == "" || strings.ContainsAny(key, " \t") {
+			return "", fmt.Errorf("invalid build arg %q, expected KEY=VAL", buildArg)
+		}
+		command = append(command, fmt.Sprintf("--build-arg %s", shellQuote(buildArg)))
+	}
+	command = append(command, fmt.Sprintf("-t %s .", shellQuote(dockerImage)))
+
+	return strings.Join(command, " "), nil
+}
+
 // @TODO - figure out port requirements
 func DockerRun(dockerImage string) error {
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
 			&shell.StringFunction{
-				Arg:      fmt.Sprintf("docker build --target final -t %s .", dockerImage),
+				Arg:      fmt.Sprintf("docker build --target final -t %s .", shellQuote(dockerImage)),
 				Function: shell.PrettyRun,
 			},
 			&shell.StringFunction{
@@ -30,7 +68,7 @@ func DockerRun(dockerImage string) error {
 				Function: DockerBuild,
 			},
 			&shell.StringFunction{
-				Arg:      fmt.Sprintf("docker run -it -P %s .", dockerImage),
+				Arg:      fmt.Sprintf("docker run -it -P %s .", shellQuote(dockerImage)),
 				Function: shell.PrettyRun,
 			},
 		},
@@ -46,7 +84,7 @@ func DockerPush(dockerImage string) error {
 				Function: DockerBuild,
 			},
 			&shell.StringFunction{
-				Arg:      fmt.Sprintf("docker push %s", dockerImage),
+				Arg:      fmt.Sprintf("docker push %s", shellQuote(dockerImage)),
 				Function: shell.PrettyRun,
 			},
 		},
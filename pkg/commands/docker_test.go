@@ -0,0 +1,66 @@
+package commands
+
+import "testing"
+
+func Test_getDockerBuildCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   string
+		opts    DockerBuildOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			opts: DockerBuildOptions{},
+			want: "docker build --target 'final' -t 'example/app:latest' .",
+		}, {
+			name: "target and build args",
+			opts: DockerBuildOptions{Target: "test", BuildArgs: []string{"GO_VERSION=1.19", "EMPTY="}},
+			want: "docker build --target 'test' --build-arg 'GO_VERSION=1.19' --build-arg 'EMPTY=' -t 'example/app:latest' .",
+		}, {
+			name: "value with spaces is quoted",
+			opts: DockerBuildOptions{BuildArgs: []string{"GREETING=hello world"}},
+			want: "docker build --target 'final' --build-arg 'GREETING=hello world' -t 'example/app:latest' .",
+		}, {
+			name: "command substitution is not expanded",
+			opts: DockerBuildOptions{BuildArgs: []string{"FOO=$(id)"}},
+			want: "docker build --target 'final' --build-arg 'FOO=$(id)' -t 'example/app:latest' .",
+		}, {
+			name: "command separator is not interpreted",
+			opts: DockerBuildOptions{BuildArgs: []string{"FOO=a;rm"}},
+			want: "docker build --target 'final' --build-arg 'FOO=a;rm' -t 'example/app:latest' .",
+		}, {
+			name: "single quote is escaped",
+			opts: DockerBuildOptions{BuildArgs: []string{"FOO=it's"}},
+			want: `docker build --target 'final' --build-arg 'FOO=it'\''s' -t 'example/app:latest' .`,
+		}, {
+			name:  "image is quoted",
+			image: "example/app:$(id)",
+			want:  "docker build --target 'final' -t 'example/app:$(id)' .",
+		}, {
+			name:    "missing equals",
+			opts:    DockerBuildOptions{BuildArgs: []string{"GO_VERSION"}},
+			wantErr: true,
+		}, {
+			name:    "missing key",
+			opts:    DockerBuildOptions{BuildArgs: []string{"=1.19"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image := tt.image
+			if image == "" {
+				image = "example/app:latest"
+			}
+			got, err := getDockerBuildCommand(image, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getDockerBuildCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getDockerBuildCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
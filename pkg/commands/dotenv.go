@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DotEnvPaths returns the .env files to load, in order of precedence: the working directory, then ~/.config/mcq
+func DotEnvPaths() []string {
+	paths := []string{".env"}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "mcq", ".env"))
+	}
+	return paths
+}
+
+// LoadDotEnv reads KEY=VALUE lines from each path and sets them in the environment.
+// Variables that are already set are never overridden, so earlier paths take precedence. Missing files are skipped.
+// Values may be quoted, and an unquoted value ends at a " #" comment.
+// Malformed lines are skipped and reported together in the returned error, after every file has been loaded
+func LoadDotEnv(paths ...string) error {
+	var problems []string
+	for _, path := range paths {
+		lineErrs, err := loadDotEnvFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		for _, lineErr := range lineErrs {
+			problems = append(problems, lineErr.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// loadDotEnvFile sets the variables in path. It returns an error per malformed line,
+// and err if the file could not be read
+func loadDotEnvFile(path string) (lineErrs []error, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" || strings.ContainsAny(key, " \t") {
+			lineErrs = append(lineErrs, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNumber))
+			continue
+		}
+
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		if err := os.Setenv(key, parseDotEnvValue(value)); err != nil {
+			lineErrs = append(lineErrs, fmt.Errorf("%s:%d: %w", path, lineNumber, err))
+		}
+	}
+	return lineErrs, scanner.Err()
+}
+
+// parseDotEnvValue returns the contents of a quoted value, or an unquoted value up to a trailing " #" comment
+func parseDotEnvValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+		return value
+	}
+
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return strings.TrimSpace(value[:i])
+		}
+	}
+	return value
+}
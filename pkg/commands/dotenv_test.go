@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadDotEnv(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "project.env")
+	global := filepath.Join(dir, "global.env")
+
+	writeFile(t, project, "# comment\n\nMCQ_TEST_PLAIN=plain # comment\nexport MCQ_TEST_EXPORTED=\"quoted value\"\nMCQ_TEST_EXISTING=from-file\nMCQ_TEST_SHARED=project\n")
+	writeFile(t, global, "MCQ_TEST_SHARED=global\nMCQ_TEST_GLOBAL='single'\n")
+
+	t.Setenv("MCQ_TEST_EXISTING", "from-env")
+	for _, key := range []string{"MCQ_TEST_PLAIN", "MCQ_TEST_EXPORTED", "MCQ_TEST_SHARED", "MCQ_TEST_GLOBAL"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	if err := LoadDotEnv(project, filepath.Join(dir, "missing.env"), global); err != nil {
+		t.Fatalf("LoadDotEnv() error = %v", err)
+	}
+
+	want := map[string]string{
+		"MCQ_TEST_PLAIN":    "plain",
+		"MCQ_TEST_EXPORTED": "quoted value",
+		"MCQ_TEST_EXISTING": "from-env",
+		"MCQ_TEST_SHARED":   "project",
+		"MCQ_TEST_GLOBAL":   "single",
+	}
+	for key, value := range want {
+		if got := os.Getenv(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestLoadDotEnvMalformed(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.env")
+	second := filepath.Join(dir, "second.env")
+	writeFile(t, first, "MCQ_TEST_BEFORE=1\nnot a variable\nMCQ_TEST_AFTER=2\n")
+	writeFile(t, second, "MCQ_TEST_SECOND=3\n")
+	for _, key := range []string{"MCQ_TEST_BEFORE", "MCQ_TEST_AFTER", "MCQ_TEST_SECOND"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	err := LoadDotEnv(first, second)
+	if err == nil || !strings.Contains(err.Error(), "first.env:2") {
+		t.Errorf("LoadDotEnv() error = %v, want the malformed line reported", err)
+	}
+	for key, value := range map[string]string{"MCQ_TEST_BEFORE": "1", "MCQ_TEST_AFTER": "2", "MCQ_TEST_SECOND": "3"} {
+		if got := os.Getenv(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func Test_parseDotEnvValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "plain", want: "plain"},
+		{value: " plain # comment", want: "plain"},
+		{value: "plain\t# comment", want: "plain"},
+		{value: "no#comment", want: "no#comment"},
+		{value: `"quoted # kept" # comment`, want: "quoted # kept"},
+		{value: "'single' # comment", want: "single"},
+		{value: `"unterminated`, want: `"unterminated`},
+		{value: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseDotEnvValue(tt.value); got != tt.want {
+				t.Errorf("parseDotEnvValue(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/stevemcquaid/mcq/pkg/shell"
 )
 
@@ -15,6 +17,33 @@ func Fmt() error {
 				Arg:      "find . -name '*.go' -not -wholename './vendor/*' | while read -r file; do goimports -w -l \"$file\"; done",
 				Function: shell.PrettyRun,
 			},
+			&shell.VoidFunction{
+				Function: SkipIfMissing("gofumpt", Fumpt),
+			},
+		},
+	)
+}
+
+// FmtCheck lists go files that gofmt or goimports would change, without modifying them.
+// It fails if there are any
+func FmtCheck() error {
+	return shell.OrderedRunner(
+		[]shell.RunningFunction{
+			&shell.StringFunction{
+				Arg:      "goimports",
+				Function: RequireBinary,
+			},
+			&shell.StringFunction{
+				Arg:      getFmtCheckCommand("gofmt", "-s -l"),
+				Function: shell.PrettyRun,
+			},
+			&shell.StringFunction{
+				Arg:      getFmtCheckCommand("goimports", "-l"),
+				Function: shell.PrettyRun,
+			},
+			&shell.VoidFunction{
+				Function: SkipIfMissing("gofumpt", FumptCheck),
+			},
 		},
 	)
 }
@@ -29,3 +58,24 @@ func Fumpt() error {
 		},
 	)
 }
+
+// FumptCheck lists go files that gofumpt would change, without modifying them.
+// It fails if there are any
+func FumptCheck() error {
+	return shell.OrderedRunner(
+		[]shell.RunningFunction{
+			&shell.StringFunction{
+				Arg:      getFmtCheckCommand("gofumpt", "-l"),
+				Function: shell.PrettyRun,
+			},
+		},
+	)
+}
+
+// getFmtCheckCommand runs formatter with flags on every non-vendor go file. It fails if the formatter lists any
+// files or exits non-zero, ie. on a file that does not parse
+func getFmtCheckCommand(formatter string, flags string) string {
+	return fmt.Sprintf("files=$(find . -name '*.go' -not -wholename './vendor/*'); if [ -z \"$files\" ]; then exit 0; fi; "+
+		"unformatted=$(%[1]s %[2]s $files); status=$?; "+
+		"if [ -n \"$unformatted\" ]; then echo \"%[1]s needed on:\"; echo \"$unformatted\"; exit 1; fi; exit $status", formatter, flags)
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stevemcquaid/mcq/pkg/shell"
+)
+
+func Test_getFmtCheckCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "no go files",
+			files: map[string]string{},
+		}, {
+			name:  "formatted",
+			files: map[string]string{"ok.go": "package x\n\nfunc A() {}\n"},
+		}, {
+			name:    "unformatted",
+			files:   map[string]string{"ok.go": "package x\n\nfunc A() {}\n", "ugly.go": "package x\nfunc  B(){}\n"},
+			wantErr: true,
+		}, {
+			name:    "malformed",
+			files:   map[string]string{"ok.go": "package x\n\nfunc A() {}\n", "bad.go": "package x\n\nfunc {\n"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeFile(t, filepath.Join(dir, name), content)
+			}
+			chdir(t, dir)
+
+			err := shell.PrettyRun(getFmtCheckCommand("gofmt", "-s -l"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("fmt check error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
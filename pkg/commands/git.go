@@ -1,9 +1,37 @@
 package commands
 
 import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
 	"github.com/stevemcquaid/mcq/pkg/shell"
 )
 
+// GitRunner runs git with args and returns its trimmed stdout
+type GitRunner interface {
+	Run(args ...string) (string, error)
+}
+
+// ExecGitRunner implements GitRunner by executing the git binary
+type ExecGitRunner struct{}
+
+func (ExecGitRunner) Run(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Git is the GitRunner used to query the repository. Tests can replace it with a fake
+var Git GitRunner = ExecGitRunner{}
+
 func Log() error {
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
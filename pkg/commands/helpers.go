@@ -3,11 +3,13 @@ package commands
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"os/user"
-	"path"
 	"strings"
 
 	modfile "golang.org/x/mod/modfile"
+
+	"github.com/stevemcquaid/mcq/pkg/shell"
 )
 
 func ReadModFile() (string, error) {
@@ -53,13 +55,54 @@ func GetUserName() (string, error) {
 	return user.Name, nil
 }
 
-func GetDockerImage() (string, error) {
-	gitOrg, gitRepo, err := GetModules()
-	if err != nil {
-		return "", err
+// GetDockerImage returns the image reference to build, run & push, tagging image with the tag resolved by GetDockerTag
+func GetDockerImage(image string, tag string) string {
+	return fmt.Sprintf("%s:%s", image, GetDockerTag(tag))
+}
+
+// GetDockerTag returns tag if set, otherwise $MCQ_DOCKER_TAG, otherwise the git short SHA, falling back to latest
+func GetDockerTag(tag string) string {
+	if tag != "" {
+		return tag
 	}
-	dockerBase := path.Join(gitOrg, gitRepo)
-	dockerImage := fmt.Sprintf("%s:%s", dockerBase, "latest")
+	if envTag := os.Getenv("MCQ_DOCKER_TAG"); envTag != "" {
+		return envTag
+	}
+
+	sha, err := Git.Run("rev-parse", "--short", "HEAD")
+	if err == nil && sha != "" {
+		return sha
+	}
+	return "latest"
+}
 
-	return dockerImage, nil
+// shellQuote wraps s in single quotes so it is passed to the shell as a single literal argument
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// RequireBinary fails with an install hint if binary is not on the PATH.
+// In a dry run the hint is printed but nothing is executed, so it is not an error
+func RequireBinary(binary string) error {
+	if _, err := exec.LookPath(binary); err != nil {
+		err = fmt.Errorf("%s not found on PATH, run `mcq setup` to install it", binary)
+		fmt.Println(err)
+		if shell.DryRun {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// SkipIfMissing returns a function which runs function only if binary is on the PATH.
+// A missing binary prints an install hint and is not an error, also in a dry run
+func SkipIfMissing(binary string, function func() error) func() error {
+	return func() error {
+		if _, err := exec.LookPath(binary); err != nil {
+			fmt.Printf("%s not found on PATH, skipping. Run `mcq setup` to install it\n", binary)
+			return nil
+		}
+		return function()
+	}
 }
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stevemcquaid/mcq/pkg/shell"
+)
+
+// fakeGit implements GitRunner with canned output
+type fakeGit struct {
+	out string
+	err error
+}
+
+func (f fakeGit) Run(args ...string) (string, error) {
+	return f.out, f.err
+}
+
+func TestGetDockerImage(t *testing.T) {
+	tests := []struct {
+		name   string
+		image  string
+		tag    string
+		envTag string
+		git    fakeGit
+		want   string
+	}{
+		{
+			name:   "explicit image and tag",
+			image:  "example/app",
+			tag:    "v1.2.3",
+			envTag: "ignored",
+			want:   "example/app:v1.2.3",
+		}, {
+			name:   "tag from environment",
+			image:  "example/app",
+			envTag: "ci-42",
+			want:   "example/app:ci-42",
+		}, {
+			name:  "tag from git short sha",
+			image: "example/app",
+			git:   fakeGit{out: "abc1234"},
+			want:  "example/app:abc1234",
+		}, {
+			name:  "latest outside a git repository",
+			image: "example/app",
+			git:   fakeGit{err: errors.New("not a git repository")},
+			want:  "example/app:latest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MCQ_DOCKER_TAG", tt.envTag)
+			defer func(git GitRunner) { Git = git }(Git)
+			Git = tt.git
+
+			if got := GetDockerImage(tt.image, tt.tag); got != tt.want {
+				t.Errorf("GetDockerImage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecGitRunner(t *testing.T) {
+	_, err := ExecGitRunner{}.Run("not-a-git-command")
+	if err == nil || !strings.Contains(err.Error(), "git not-a-git-command failed") {
+		t.Errorf("ExecGitRunner.Run() error = %v, want it to name the failed command", err)
+	}
+}
+
+func TestRequireBinaryDryRun(t *testing.T) {
+	defer func(dryRun bool) { shell.DryRun = dryRun }(shell.DryRun)
+	shell.DryRun = true
+
+	if err := RequireBinary("mcq-binary-that-does-not-exist"); err != nil {
+		t.Errorf("RequireBinary() in dry run error = %v, want nil", err)
+	}
+}
+
+func TestRequireBinary(t *testing.T) {
+	if err := RequireBinary("go"); err != nil {
+		t.Errorf("RequireBinary(go) error = %v", err)
+	}
+
+	err := RequireBinary("mcq-binary-that-does-not-exist")
+	if err == nil || !strings.Contains(err.Error(), "mcq setup") {
+		t.Errorf("RequireBinary() error = %v, want an install hint", err)
+	}
+}
+
+func TestSkipIfMissing(t *testing.T) {
+	tests := []struct {
+		name    string
+		binary  string
+		wantRan bool
+	}{
+		{name: "installed binary runs", binary: "go", wantRan: true},
+		{name: "missing binary is skipped", binary: "mcq-binary-that-does-not-exist", wantRan: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran := false
+			err := SkipIfMissing(tt.binary, func() error {
+				ran = true
+				return nil
+			})()
+			if err != nil {
+				t.Fatalf("SkipIfMissing() error = %v", err)
+			}
+			if ran != tt.wantRan {
+				t.Errorf("SkipIfMissing() ran = %v, want %v", ran, tt.wantRan)
+			}
+		})
+	}
+}
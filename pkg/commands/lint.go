@@ -29,6 +29,10 @@ var StaticCheckCommand = []string{
 func StaticCheck() error {
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
+			&shell.StringFunction{
+				Arg:      "staticcheck",
+				Function: RequireBinary,
+			},
 			&shell.StringFunction{
 				Arg:      strings.Join(StaticCheckCommand, " "),
 				Function: shell.PrettyRun,
@@ -85,6 +89,10 @@ func getGolangCICommandWithFix(fix bool) string {
 func GolangCI(fix bool) error {
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
+			&shell.StringFunction{
+				Arg:      "golangci-lint",
+				Function: RequireBinary,
+			},
 			&shell.StringFunction{
 				Arg:      getGolangCICommandWithFix(fix),
 				Function: shell.PrettyRun,
@@ -126,6 +134,14 @@ func ReviewDog(pr int, suggest bool) error {
 
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
+			&shell.StringFunction{
+				Arg:      "golangci-lint",
+				Function: RequireBinary,
+			},
+			&shell.StringFunction{
+				Arg:      "reviewdog",
+				Function: RequireBinary,
+			},
 			&shell.StringFunction{
 				Arg:      strings.Join(command, ""),
 				Function: shell.PrettyRun,
@@ -1,33 +1,77 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/stevemcquaid/mcq/pkg/shell"
 )
 
+// TestOptions customizes the go test invocation used by the unit tests
+type TestOptions struct {
+	// Race enables the race detector
+	Race bool
+	// Run is the -run pattern, defaults to "."
+	Run string
+	// Pkg scopes the tests to a single package, defaults to every non-vendor package
+	Pkg string
+}
+
 func Test() error {
+	return TestWithOptions(TestOptions{})
+}
+
+func TestWithOptions(opts TestOptions) error {
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
 			&shell.VoidFunction{
 				Function: Deps,
 			},
 			&shell.VoidFunction{
-				Function: TestUnit,
+				Function: func() error {
+					return TestUnitWithOptions(opts)
+				},
 			},
 		},
 	)
 }
 
 func TestUnit() error {
+	return TestUnitWithOptions(TestOptions{})
+}
+
+func TestUnitWithOptions(opts TestOptions) error {
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
 			&shell.StringFunction{
-				Arg:      "go test -cover -covermode=atomic -coverprofile=build/unit.out $(go list ./... | grep -v /vendor/)  -run .",
+				Arg:      getTestUnitCommand(opts),
 				Function: shell.PrettyRun,
 			},
 		},
 	)
 }
 
+func getTestUnitCommand(opts TestOptions) string {
+	command := []string{"go test -cover -covermode=atomic -coverprofile=build/unit.out"}
+	if opts.Race {
+		command = append(command, "-race")
+	}
+
+	if opts.Pkg != "" {
+		command = append(command, shellQuote(opts.Pkg))
+	} else {
+		command = append(command, "$(go list ./... | grep -v /vendor/)")
+	}
+
+	run := opts.Run
+	if run == "" {
+		run = "."
+	}
+	command = append(command, fmt.Sprintf("-run %s", shellQuote(run)))
+
+	return strings.Join(command, " ")
+}
+
 func TestIntegration() error {
 	return shell.OrderedRunner(
 		[]shell.RunningFunction{
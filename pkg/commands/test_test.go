@@ -0,0 +1,36 @@
+package commands
+
+import "testing"
+
+func Test_getTestUnitCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		opts TestOptions
+		want string
+	}{
+		{
+			name: "defaults",
+			opts: TestOptions{},
+			want: "go test -cover -covermode=atomic -coverprofile=build/unit.out $(go list ./... | grep -v /vendor/) -run '.'",
+		}, {
+			name: "race",
+			opts: TestOptions{Race: true},
+			want: "go test -cover -covermode=atomic -coverprofile=build/unit.out -race $(go list ./... | grep -v /vendor/) -run '.'",
+		}, {
+			name: "run pattern and package",
+			opts: TestOptions{Run: "TestFoo|TestBar$", Pkg: "./pkg/shell"},
+			want: "go test -cover -covermode=atomic -coverprofile=build/unit.out './pkg/shell' -run 'TestFoo|TestBar$'",
+		}, {
+			name: "run pattern with a quote",
+			opts: TestOptions{Run: "it's"},
+			want: `go test -cover -covermode=atomic -coverprofile=build/unit.out $(go list ./... | grep -v /vendor/) -run 'it'\''s'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getTestUnitCommand(tt.opts); got != tt.want {
+				t.Errorf("getTestUnitCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
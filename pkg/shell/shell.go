@@ -6,9 +6,11 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
 
 	"github.com/fatih/color"
-	// "github.com/fatih/color"
 	"github.com/segmentio/textio"
 
 	"github.com/stevemcquaid/mcq/pkg/colorwriter"
@@ -16,11 +18,19 @@ import (
 
 const ShellToUse = "sh"
 
+// DryRun makes PrettyRun print each command without executing it
+var DryRun bool
+
+// PrettyRun runs command in a shell, streaming each line of its output to stdout as soon as it is complete.
+// On failure the returned error includes the combined stdout/stderr of the command
 // @TODO - create different pretty printers without the runner command. and use them inside the prettyrun()
 func PrettyRun(command string) error {
 	greenColorWriter := colorwriter.NewPrefixWriter(os.Stdout, color.New(color.FgGreen))
 	defer greenColorWriter.Flush()
 	_, _ = fmt.Fprintf(greenColorWriter, "===> %s\n", command)
+	if DryRun {
+		return nil
+	}
 
 	blueColorWriter := colorwriter.NewPrefixWriter(os.Stdout, color.New(color.FgCyan))
 	defer blueColorWriter.Flush()
@@ -35,30 +45,39 @@ func PrettyRun(command string) error {
 
 	cmd := exec.Command(ShellToUse, "-c", command)
 
-	var stdoutBuf, stderrBuf bytes.Buffer
-	cmd.Stdout = io.MultiWriter(stdOutWriter, &stdoutBuf)
-	cmd.Stderr = io.MultiWriter(stdErrWriter, &stderrBuf)
+	// stdout and stderr are copied by separate goroutines, so the combined output must be synchronized
+	var output syncBuffer
+	cmd.Stdout = io.MultiWriter(stdOutWriter, &output)
+	cmd.Stderr = io.MultiWriter(stdErrWriter, &output)
 
 	err := cmd.Run()
 	if err != nil {
 		fmt.Fprintln(redColorWriter, "------ cmd.Run() failed ------")
 		fmt.Fprintln(stdErrWriter, err)
 
-		return err
-
-		// outStr, errStr := string(stdoutBuf.Bytes()), string(stderrBuf.Bytes())
-		// if outStr != "" {
-		// 	fmt.Println("------ stdout ---")
-		// 	fmt.Println(outStr)
-		// }
-		// if errStr != "" {
-		// 	fmt.Println("------ stderr ---")
-		// 	fmt.Println(errStr)
-		// }
+		return fmt.Errorf("%q failed: %w\n%s", command, err, strings.TrimRight(output.String(), "\n"))
 	}
 	return nil
 }
 
+// syncBuffer is a bytes.Buffer that is safe to write to from multiple goroutines
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
 // RunningFunction defines a generic interface to run functions
 type RunningFunction interface {
 	Run() error
@@ -75,6 +94,62 @@ func OrderedRunner(queue []RunningFunction) error {
 	return nil
 }
 
+// OrderedRunnerIgnoreErrors takes an array of objects of type RunningFunction and tells each to run in sequence,
+// continuing past errors. It returns a single error listing each failure in queue order
+func OrderedRunnerIgnoreErrors(queue []RunningFunction) error {
+	errs := make([]error, len(queue))
+	for i, item := range queue {
+		errs[i] = item.Run()
+	}
+	return aggregateErrors(errs)
+}
+
+// ParallelRunner takes an array of objects of type RunningFunction and runs them concurrently on a pool of
+// runtime.NumCPU() workers. It waits for every function to finish and returns a single error listing each
+// failure in queue order
+func ParallelRunner(queue []RunningFunction) error {
+	errs := make([]error, len(queue))
+	indexes := make(chan int)
+
+	workers := runtime.NumCPU()
+	if workers > len(queue) {
+		workers = len(queue)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = queue[i].Run()
+			}
+		}()
+	}
+
+	for i := range queue {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return aggregateErrors(errs)
+}
+
+// aggregateErrors combines the non-nil errors into one, preserving their order. It returns nil if there were none
+func aggregateErrors(errs []error) error {
+	var messages []string
+	for i, err := range errs {
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("[%d] %s", i, err))
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d functions failed:\n%s", len(messages), len(errs), strings.Join(messages, "\n"))
+}
+
 // StringSliceFunction implements RunningFunction interface, and supports Functions with a single string argument
 type StringSliceFunction struct {
 	Arg      []string
@@ -1,7 +1,12 @@
 package shell
 
 import (
+	"errors"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestPrettyRun(t *testing.T) {
@@ -39,3 +44,136 @@ func TestPrettyRun(t *testing.T) {
 		})
 	}
 }
+
+func TestParallelRunner(t *testing.T) {
+	fail := func(msg string) RunningFunction {
+		return &VoidFunction{Function: func() error { return errors.New(msg) }}
+	}
+	pass := &VoidFunction{Function: func() error { return nil }}
+
+	tests := []struct {
+		name    string
+		queue   []RunningFunction
+		wantErr string
+	}{
+		{
+			name:  "empty queue",
+			queue: []RunningFunction{},
+		}, {
+			name:  "all pass",
+			queue: []RunningFunction{pass, pass, pass},
+		}, {
+			name:    "failures are reported in queue order",
+			queue:   []RunningFunction{fail("first"), pass, fail("third")},
+			wantErr: "2 of 3 functions failed:\n[0] first\n[2] third",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ParallelRunner(tt.queue)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParallelRunner() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ParallelRunner() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrettyRunErrorIncludesOutput(t *testing.T) {
+	err := PrettyRun("echo to-stdout; echo to-stderr 1>&2; exit 3")
+	if err == nil {
+		t.Fatal("PrettyRun() expected an error")
+	}
+	for _, want := range []string{"exit status 3", "to-stdout", "to-stderr"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("PrettyRun() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestOrderedRunnerIgnoreErrors(t *testing.T) {
+	var ran []string
+	step := func(name string, err error) RunningFunction {
+		return &VoidFunction{Function: func() error {
+			ran = append(ran, name)
+			return err
+		}}
+	}
+
+	err := OrderedRunnerIgnoreErrors([]RunningFunction{
+		step("first", errors.New("first failed")),
+		step("second", nil),
+		step("third", errors.New("third failed")),
+	})
+
+	if got := strings.Join(ran, ","); got != "first,second,third" {
+		t.Errorf("ran = %q, want every step in order", got)
+	}
+	want := "2 of 3 functions failed:\n[0] first failed\n[2] third failed"
+	if err == nil || err.Error() != want {
+		t.Errorf("OrderedRunnerIgnoreErrors() error = %v, want %q", err, want)
+	}
+}
+
+func TestPrettyRunDryRun(t *testing.T) {
+	defer func(dryRun bool) { DryRun = dryRun }(DryRun)
+	DryRun = true
+
+	if err := PrettyRun("exit 1"); err != nil {
+		t.Errorf("PrettyRun() in dry run error = %v, want the command to be skipped", err)
+	}
+}
+
+func TestParallelRunnerConcurrency(t *testing.T) {
+	workers := runtime.NumCPU()
+
+	var running, maxRunning atomic.Int32
+	release := make(chan struct{})
+	blocking := &VoidFunction{Function: func() error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		<-release
+		return nil
+	}}
+
+	queue := make([]RunningFunction, 3*workers)
+	for i := range queue {
+		queue[i] = blocking
+	}
+
+	done := make(chan error)
+	go func() { done <- ParallelRunner(queue) }()
+
+	// every worker should pick up a function, and no more than that should start while they are all blocked
+	deadline := time.After(5 * time.Second)
+	for running.Load() < int32(workers) {
+		select {
+		case <-deadline:
+			t.Fatalf("only %d functions running concurrently, want %d", running.Load(), workers)
+		case <-time.After(time.Millisecond):
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := running.Load(); got != int32(workers) {
+		t.Errorf("functions running while blocked = %d, want runtime.NumCPU() = %d", got, workers)
+	}
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("ParallelRunner() error = %v", err)
+	}
+	if got := maxRunning.Load(); got != int32(workers) {
+		t.Errorf("max concurrently running = %d, want runtime.NumCPU() = %d", got, workers)
+	}
+}